@@ -0,0 +1,102 @@
+package testexpect
+
+import (
+	"bytes"
+	"reflect"
+)
+
+// equalValues reports whether a and b can be considered equal once convertible
+// types are taken into account: either equal() already accepts them, or one value
+// is convertible to the other's type without losing information and the converted
+// values compare equal
+func equalValues(a interface{}, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	if ab, bb := asByteSlice(a), asByteSlice(b); ab != nil && bb != nil {
+		return bytes.Equal(ab, bb)
+	}
+
+	if safeEqual(a, b) {
+		return true
+	}
+
+	// a and b are both types equal() already fully understands (including its
+	// signed/unsigned/float cross-comparisons), so there's nothing a raw type
+	// conversion could add
+	if recognizedByGetType(a) && recognizedByGetType(b) {
+		return false
+	}
+
+	av := reflect.ValueOf(a)
+	bv := reflect.ValueOf(b)
+
+	if av.Type().ConvertibleTo(bv.Type()) && losslessConvert(av, bv.Type()) {
+		converted := av.Convert(bv.Type()).Interface()
+		if safeEqual(converted, b) || reflect.DeepEqual(converted, b) {
+			return true
+		}
+	}
+
+	if bv.Type().ConvertibleTo(av.Type()) && losslessConvert(bv, av.Type()) {
+		converted := bv.Convert(av.Type()).Interface()
+		if safeEqual(a, converted) || reflect.DeepEqual(a, converted) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// losslessConvert reports whether converting v to type to and back to v's own type
+// reproduces the original value and keeps its sign, i.e. the conversion doesn't lose
+// information. A conversion that can't be reversed reports false
+func losslessConvert(v reflect.Value, to reflect.Type) bool {
+	if !to.ConvertibleTo(v.Type()) {
+		return false
+	}
+	converted := v.Convert(to)
+	isSigned := func(k reflect.Kind) bool { return k >= reflect.Int && k <= reflect.Int64 }
+	isUnsigned := func(k reflect.Kind) bool { return k >= reflect.Uint && k <= reflect.Uintptr }
+	if isSigned(v.Kind()) && isUnsigned(to.Kind()) && v.Int() < 0 {
+		return false
+	}
+	if isUnsigned(v.Kind()) && isSigned(to.Kind()) && converted.Int() < 0 {
+		return false
+	}
+	back := converted.Convert(v.Type())
+	return reflect.DeepEqual(back.Interface(), v.Interface())
+}
+
+// asByteSlice returns v as a []byte if it is one, or nil otherwise
+func asByteSlice(v interface{}) []byte {
+	b, ok := v.([]byte)
+	if !ok {
+		return nil
+	}
+	return b
+}
+
+// safeEqual calls equal(), recovering and reporting false if the values' types
+// aren't ones equal() understands
+func safeEqual(a interface{}, b interface{}) (result bool) {
+	defer func() {
+		if recover() != nil {
+			result = false
+		}
+	}()
+	return equal(a, b)
+}
+
+// recognizedByGetType reports whether v is one of the concrete types getType()
+// understands (as opposed to, say, a named type with an underlying numeric kind)
+func recognizedByGetType(v interface{}) (ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	getType(v)
+	return true
+}
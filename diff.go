@@ -0,0 +1,194 @@
+package testexpect
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unsafe"
+)
+
+// maxSliceDiffIndices caps how many differing indices SliceEqual reports per
+// failure, so a pair of wildly different large slices doesn't flood the output
+const maxSliceDiffIndices = 10
+
+// prettyPrint renders v as a stable, indented, multi-line representation suitable
+// for diffing: map keys are sorted, unexported struct fields are included, and
+// cyclic references are detected rather than recursed into forever
+func prettyPrint(v interface{}) string {
+	var b strings.Builder
+	writePretty(&b, reflect.ValueOf(v), 0, make(map[uintptr]bool))
+	return b.String()
+}
+
+// diffString renders a unified line diff between the pretty-printed forms of
+// expected and actual, for use in assertion failure messages
+func diffString(expected interface{}, actual interface{}) string {
+	return strings.Join(diffLines(prettyPrint(expected), prettyPrint(actual)), "\n")
+}
+
+// writePretty writes a pretty-printed representation of v to b, indenting nested
+// values by indent levels and guarding against cycles via visited, which tracks
+// the addresses of pointers, maps, and slices currently being printed
+func writePretty(b *strings.Builder, v reflect.Value, indent int, visited map[uintptr]bool) {
+	pad := strings.Repeat("  ", indent)
+
+	if !v.IsValid() {
+		b.WriteString("nil")
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			b.WriteString("nil")
+			return
+		}
+		addr := v.Pointer()
+		if visited[addr] {
+			b.WriteString("<cycle>")
+			return
+		}
+		visited[addr] = true
+		b.WriteString("&")
+		writePretty(b, v.Elem(), indent, visited)
+		delete(visited, addr)
+
+	case reflect.Interface:
+		if v.IsNil() {
+			b.WriteString("nil")
+			return
+		}
+		writePretty(b, v.Elem(), indent, visited)
+
+	case reflect.Struct:
+		sv := v
+		if !sv.CanAddr() {
+			rv := reflect.New(sv.Type())
+			rv.Elem().Set(sv)
+			sv = rv.Elem()
+		}
+
+		t := sv.Type()
+		b.WriteString(t.String())
+		b.WriteString("{\n")
+		for i := 0; i < t.NumField(); i++ {
+			f := sv.Field(i)
+			if !f.CanInterface() {
+				f = reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem()
+			}
+			b.WriteString(pad + "  " + t.Field(i).Name + ": ")
+			writePretty(b, f, indent+1, visited)
+			b.WriteString(",\n")
+		}
+		b.WriteString(pad + "}")
+
+	case reflect.Map:
+		if v.IsNil() {
+			b.WriteString("nil")
+			return
+		}
+		addr := v.Pointer()
+		if visited[addr] {
+			b.WriteString("<cycle>")
+			return
+		}
+		visited[addr] = true
+
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprintf("%v", keys[i].Interface()) < fmt.Sprintf("%v", keys[j].Interface())
+		})
+
+		b.WriteString(v.Type().String())
+		b.WriteString("{\n")
+		for _, k := range keys {
+			b.WriteString(pad + "  " + fmt.Sprintf("%v", k.Interface()) + ": ")
+			writePretty(b, v.MapIndex(k), indent+1, visited)
+			b.WriteString(",\n")
+		}
+		b.WriteString(pad + "}")
+		delete(visited, addr)
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice {
+			if v.IsNil() {
+				b.WriteString("nil")
+				return
+			}
+			addr := v.Pointer()
+			if visited[addr] {
+				b.WriteString("<cycle>")
+				return
+			}
+			if v.Len() > 0 {
+				visited[addr] = true
+				defer delete(visited, addr)
+			}
+		}
+
+		b.WriteString(v.Type().String())
+		b.WriteString("{\n")
+		for i := 0; i < v.Len(); i++ {
+			b.WriteString(pad + "  ")
+			writePretty(b, v.Index(i), indent+1, visited)
+			b.WriteString(",\n")
+		}
+		b.WriteString(pad + "}")
+
+	case reflect.String:
+		b.WriteString(fmt.Sprintf("%q", v.String()))
+
+	default:
+		b.WriteString(fmt.Sprintf("%v", v.Interface()))
+	}
+}
+
+// diffLines computes a unified line diff between a and b via a longest-common-
+// subsequence alignment, returning lines prefixed with "- " (only in a), "+ "
+// (only in b), or "  " (common to both)
+func diffLines(a string, b string) []string {
+	al := strings.Split(a, "\n")
+	bl := strings.Split(b, "\n")
+	n, m := len(al), len(bl)
+
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if al[i] == bl[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	out := make([]string, 0, n+m)
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case al[i] == bl[j]:
+			out = append(out, "  "+al[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, "- "+al[i])
+			i++
+		default:
+			out = append(out, "+ "+bl[j])
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		out = append(out, "- "+al[i])
+	}
+	for ; j < m; j++ {
+		out = append(out, "+ "+bl[j])
+	}
+	return out
+}
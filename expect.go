@@ -1,12 +1,13 @@
 package testexpect
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"path/filepath"
 	"reflect"
 	"runtime"
-	"testing"
+	"strings"
 )
 
 // comparisonType is a base type of a value for comparison
@@ -30,17 +31,104 @@ type Expect interface {
 	NotDeepEqual(name string, actual interface{}, expected interface{})
 	Equal(name string, actual interface{}, expected interface{})
 	NotEqual(name string, actual interface{}, expected interface{})
+	EqualValues(name string, actual interface{}, expected interface{})
 	SliceEqual(name string, actual interface{}, expected interface{})
+	Greater(name string, actual interface{}, expected interface{})
+	GreaterOrEqual(name string, actual interface{}, expected interface{})
+	Less(name string, actual interface{}, expected interface{})
+	LessOrEqual(name string, actual interface{}, expected interface{})
+	Between(name string, actual interface{}, low interface{}, high interface{})
+	InDelta(name string, actual float64, expected float64, delta float64)
+	InEpsilon(name string, actual float64, expected float64, epsilon float64)
+	Contains(name string, container interface{}, element interface{})
+	Len(name string, container interface{}, expectedLen int)
+	Empty(name string, actual interface{})
+	NotEmpty(name string, actual interface{})
+	ElementsMatch(name string, actual interface{}, expected interface{})
+	Subset(name string, superset interface{}, subset interface{})
+	Panics(action string, fn func())
+	NotPanics(action string, fn func())
+	PanicsWithValue(action string, expected interface{}, fn func())
+	ErrorIs(action string, err error, target error)
+	ErrorAs(action string, err error, target interface{})
+	ErrorContains(action string, err error, substring string)
+}
+
+// TestingT is the subset of *testing.T that this package depends on, allowing
+// alternative implementations (e.g. for capturing failures in a harness) to be
+// substituted for NewContext and NewAssertContext
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+	Fatalf(format string, args ...interface{})
+	Helper()
+}
+
+// Option configures a context created by NewContext or NewAssertContext
+type Option func(*context)
+
+// WithColor enables or disables the ANSI color codes used in failure messages.
+// Color is enabled by default; disable it for CI systems that strip or mangle
+// ANSI escapes
+func WithColor(enabled bool) Option {
+	return func(c *context) {
+		c.color = enabled
+	}
+}
+
+// WithPrefix sets a string to prepend to every failure message
+func WithPrefix(prefix string) Option {
+	return func(c *context) {
+		c.prefix = prefix
+	}
+}
+
+// WithMaxFailures caps the number of failures a non-fatal context (see
+// NewAssertContext) will record before it calls Fatalf to abort the test. A value
+// of 0, the default, means unlimited
+func WithMaxFailures(max int) Option {
+	return func(c *context) {
+		c.maxFailures = max
+	}
+}
+
+// WithOptions bundles several Options into one, for convenience when passing a
+// shared set of options to more than one context
+func WithOptions(opts ...Option) Option {
+	return func(c *context) {
+		for _, opt := range opts {
+			opt(c)
+		}
+	}
 }
 
 // context describes a testing context
 type context struct {
-	t *testing.T
+	t           TestingT
+	fatal       bool
+	color       bool
+	prefix      string
+	maxFailures int
+	failures    int
+}
+
+// NewContext creates a new testing context that aborts the test via Fatalf on the
+// first failed assertion
+func NewContext(t TestingT, opts ...Option) Expect {
+	c := &context{t: t, fatal: true, color: true}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
-// NewContext creates a new testing context
-func NewContext(t *testing.T) Expect {
-	return &context{t: t}
+// NewAssertContext creates a new testing context that records failures via Errorf
+// and lets the test continue, so multiple assertions can be checked per test
+func NewAssertContext(t TestingT, opts ...Option) Expect {
+	c := &context{t: t, fatal: false, color: true}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // Nil asserts that the given value is nil
@@ -67,14 +155,14 @@ func (c *context) NoError(action string, err error) {
 // DeepEqual asserts that the two given values are equal
 func (c *context) DeepEqual(name string, actual interface{}, expected interface{}) {
 	if !reflect.DeepEqual(actual, expected) {
-		c.fail(1, "expected %s to equal %v, got %v", name, expected, actual)
+		c.fail(1, "expected %s to equal:\n%s", name, diffString(expected, actual))
 	}
 }
 
 // NotDeepEqual asserts that the two given values are not equal
 func (c *context) NotDeepEqual(name string, actual interface{}, notExpected interface{}) {
 	if reflect.DeepEqual(actual, notExpected) {
-		c.fail(1, "expected %s to not equal %v", name, notExpected)
+		c.fail(1, "expected %s to not equal:\n%s", name, prettyPrint(notExpected))
 	}
 }
 
@@ -92,18 +180,309 @@ func (c *context) NotEqual(name string, actual interface{}, notExpected interfac
 	}
 }
 
+// EqualValues asserts that the two given values are equal, allowing for values of
+// convertible types, such as a named type and its underlying type, or two byte
+// slices, to compare equal as long as the conversion doesn't lose information
+func (c *context) EqualValues(name string, actual interface{}, expected interface{}) {
+	if !equalValues(actual, expected) {
+		c.fail(1, "expected %s to equal %v (by value), got %v", name, expected, actual)
+	}
+}
+
 // SliceEqual asserts that the two given slices have the same values at the same indices.
 func (c *context) SliceEqual(name string, actual interface{}, expected interface{}) {
 	aslice := interfaceSlice(actual)
 	eslice := interfaceSlice(expected)
 
+	var msgs []string
 	if len(aslice) != len(eslice) {
-		c.fail(1, "expected len(%s) to be %d, got %d", name, len(eslice), len(aslice))
+		msgs = append(msgs, fmt.Sprintf("expected len(%s) to be %d, got %d", name, len(eslice), len(aslice)))
 	}
-	for i, v := range aslice {
-		if !equal(v, eslice[i]) {
-			c.fail(1, "expected %s[%d] to equal %v, got %v", name, i, eslice, v)
+
+	n := len(aslice)
+	if len(eslice) < n {
+		n = len(eslice)
+	}
+	shown := 0
+	for i := 0; i < n; i++ {
+		if equal(aslice[i], eslice[i]) {
+			continue
+		}
+		if shown == maxSliceDiffIndices {
+			msgs = append(msgs, fmt.Sprintf("... and more differing indices in %s", name))
+			break
 		}
+		msgs = append(msgs, fmt.Sprintf("%s[%d]:\n%s", name, i, diffString(eslice[i], aslice[i])))
+		shown++
+	}
+
+	if len(msgs) > 0 {
+		c.fail(1, "%s", strings.Join(msgs, "\n"))
+	}
+}
+
+// Greater asserts that actual is greater than expected, comparing numbers across
+// signed/unsigned/float types or strings lexicographically
+func (c *context) Greater(name string, actual interface{}, expected interface{}) {
+	if orderedCompare(actual, expected) >= 0 {
+		c.fail(1, "expected %s (%v) to be greater than %v", name, actual, expected)
+	}
+}
+
+// GreaterOrEqual asserts that actual is greater than or equal to expected
+func (c *context) GreaterOrEqual(name string, actual interface{}, expected interface{}) {
+	if orderedCompare(actual, expected) > 0 {
+		c.fail(1, "expected %s (%v) to be greater than or equal to %v", name, actual, expected)
+	}
+}
+
+// Less asserts that actual is less than expected
+func (c *context) Less(name string, actual interface{}, expected interface{}) {
+	if orderedCompare(actual, expected) <= 0 {
+		c.fail(1, "expected %s (%v) to be less than %v", name, actual, expected)
+	}
+}
+
+// LessOrEqual asserts that actual is less than or equal to expected
+func (c *context) LessOrEqual(name string, actual interface{}, expected interface{}) {
+	if orderedCompare(actual, expected) < 0 {
+		c.fail(1, "expected %s (%v) to be less than or equal to %v", name, actual, expected)
+	}
+}
+
+// Between asserts that actual falls within the inclusive range [low, high]
+func (c *context) Between(name string, actual interface{}, low interface{}, high interface{}) {
+	if orderedCompare(actual, low) > 0 {
+		c.fail(1, "expected %s (%v) to be between %v and %v", name, actual, low, high)
+	} else if orderedCompare(actual, high) < 0 {
+		c.fail(1, "expected %s (%v) to be between %v and %v", name, actual, low, high)
+	}
+}
+
+// InDelta asserts that actual and expected differ by no more than delta. A NaN on
+// either side is never within range of anything, including itself
+func (c *context) InDelta(name string, actual float64, expected float64, delta float64) {
+	if math.IsNaN(actual) || math.IsNaN(expected) {
+		c.fail(1, "expected %s (%v) to be within %v of %v", name, actual, delta, expected)
+		return
+	}
+	if diff := math.Abs(actual - expected); diff > delta {
+		c.fail(1, "expected %s (%v) to be within %v of %v, got a difference of %v", name, actual, delta, expected, diff)
+	}
+}
+
+// InEpsilon asserts that actual and expected differ by no more than epsilon relative
+// to expected, i.e. |actual-expected|/|expected| <= epsilon
+func (c *context) InEpsilon(name string, actual float64, expected float64, epsilon float64) {
+	if math.IsNaN(actual) || math.IsNaN(expected) {
+		c.fail(1, "expected %s (%v) to be within relative epsilon %v of %v", name, actual, epsilon, expected)
+		return
+	}
+	if expected == 0 {
+		c.fail(1, "expected %s (%v) to be within relative epsilon %v of %v, but expected is zero", name, actual, epsilon, expected)
+		return
+	}
+	if relDiff := math.Abs(actual-expected) / math.Abs(expected); relDiff > epsilon {
+		c.fail(1, "expected %s (%v) to be within relative epsilon %v of %v, got a relative difference of %v", name, actual, epsilon, expected, relDiff)
+	}
+}
+
+// Contains asserts that container holds element: a substring for strings, a member
+// for slices and arrays, or a key for maps
+func (c *context) Contains(name string, container interface{}, element interface{}) {
+	if !contains(container, element) {
+		c.fail(1, "expected %s (%v) to contain %v", name, container, element)
+	}
+}
+
+// Len asserts that container has the expected length. container must be a string,
+// slice, array, map, or chan
+func (c *context) Len(name string, container interface{}, expectedLen int) {
+	l := reflect.ValueOf(container).Len()
+	if l != expectedLen {
+		c.fail(1, "expected len(%s) to be %d, got %d", name, expectedLen, l)
+	}
+}
+
+// Empty asserts that actual is the zero value for its type, or has zero length if
+// it is a string, slice, array, map, or chan. A non-nil pointer is empty if the
+// value it points to is
+func (c *context) Empty(name string, actual interface{}) {
+	if !isEmpty(actual) {
+		c.fail(1, "expected %s to be empty, got %v", name, actual)
+	}
+}
+
+// NotEmpty asserts that actual is not the zero value for its type, and does not have
+// zero length if it is a string, slice, array, map, or chan. A non-nil pointer is
+// not empty only if the value it points to is not
+func (c *context) NotEmpty(name string, actual interface{}) {
+	if isEmpty(actual) {
+		c.fail(1, "expected %s to not be empty", name)
+	}
+}
+
+// ElementsMatch asserts that actual and expected contain the same elements,
+// regardless of order or duplicates' position
+func (c *context) ElementsMatch(name string, actual interface{}, expected interface{}) {
+	aslice := interfaceSlice(actual)
+	eslice := interfaceSlice(expected)
+
+	if len(aslice) != len(eslice) {
+		c.fail(1, "expected %s to have the same elements as %v, got %v", name, expected, actual)
+		return
+	}
+
+	visited := make([]bool, len(eslice))
+	for _, av := range aslice {
+		found := false
+		for i, ev := range eslice {
+			if visited[i] {
+				continue
+			}
+			if equal(av, ev) {
+				visited[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			c.fail(1, "expected %s to have the same elements as %v, got %v", name, expected, actual)
+			return
+		}
+	}
+}
+
+// Subset asserts that every element of subset is present in superset
+func (c *context) Subset(name string, superset interface{}, subset interface{}) {
+	supslice := interfaceSlice(superset)
+	subslice := interfaceSlice(subset)
+
+	for _, sv := range subslice {
+		found := false
+		for _, pv := range supslice {
+			if equal(sv, pv) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			c.fail(1, "expected %s (%v) to be a subset of %v", name, subset, superset)
+			return
+		}
+	}
+}
+
+// contains reports whether container holds element: a substring for strings, a
+// member for slices and arrays, or a key for maps
+func contains(container interface{}, element interface{}) bool {
+	if s, ok := container.(string); ok {
+		switch e := element.(type) {
+		case string:
+			return strings.Contains(s, e)
+		default:
+			return strings.Contains(s, fmt.Sprintf("%v", e))
+		}
+	}
+
+	v := reflect.ValueOf(container)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if equal(v.Index(i).Interface(), element) {
+				return true
+			}
+		}
+		return false
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			if equal(k.Interface(), element) {
+				return true
+			}
+		}
+		return false
+	default:
+		panic(fmt.Sprintf("can't check containment on %T", container))
+	}
+}
+
+// isEmpty reports whether the given value is the zero value for its type, or has
+// zero length if it is a string, slice, array, map, or chan. A non-nil pointer is
+// followed and the value it points to is checked instead
+func isEmpty(object interface{}) bool {
+	if object == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(object)
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array, reflect.Chan:
+		return v.Len() == 0
+	case reflect.Ptr:
+		if v.IsNil() {
+			return true
+		}
+		return isEmpty(v.Elem().Interface())
+	default:
+		return reflect.DeepEqual(object, reflect.Zero(v.Type()).Interface())
+	}
+}
+
+// Panics asserts that fn panics while performing action. The reported location is
+// the caller of Panics, not the deferred recover
+func (c *context) Panics(action string, fn func()) {
+	defer func() {
+		if r := recover(); r == nil {
+			c.fail(2, "expected a panic while %s", action)
+		}
+	}()
+	fn()
+}
+
+// NotPanics asserts that fn does not panic while performing action
+func (c *context) NotPanics(action string, fn func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.fail(2, "expected no panic while %s, got %v", action, r)
+		}
+	}()
+	fn()
+}
+
+// PanicsWithValue asserts that fn panics with exactly the expected value while
+// performing action
+func (c *context) PanicsWithValue(action string, expected interface{}, fn func()) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			c.fail(2, "expected a panic while %s", action)
+		} else if !reflect.DeepEqual(r, expected) {
+			c.fail(2, "expected panic value %v while %s, got %v", expected, action, r)
+		}
+	}()
+	fn()
+}
+
+// ErrorIs asserts that err or one of the errors it wraps matches target, as
+// determined by errors.Is
+func (c *context) ErrorIs(action string, err error, target error) {
+	if !errors.Is(err, target) {
+		c.fail(1, "expected error while %s to match %v, got %v", action, target, err)
+	}
+}
+
+// ErrorAs asserts that err or one of the errors it wraps can be assigned to target,
+// as determined by errors.As
+func (c *context) ErrorAs(action string, err error, target interface{}) {
+	if !errors.As(err, target) {
+		c.fail(1, "expected error while %s to be assignable to %T, got %v", action, target, err)
+	}
+}
+
+// ErrorContains asserts that err is non-nil and its message contains substring
+func (c *context) ErrorContains(action string, err error, substring string) {
+	if err == nil || !strings.Contains(err.Error(), substring) {
+		c.fail(1, "expected error while %s to contain %q, got %v", action, substring, err)
 	}
 }
 
@@ -122,10 +501,26 @@ func interfaceSlice(slice interface{}) []interface{} {
 	return ret
 }
 
-// fail fails the test
+// fail fails the test, either stopping it immediately or recording the failure and
+// continuing, depending on how the context was constructed
 func (c *context) fail(stackDepth int, format string, args ...interface{}) {
+	c.t.Helper()
 	frame := getCallerFrame(stackDepth + 1)
-	c.t.Fatalf("\033[0;31m%s:%d FAIL %s\033[0m", filepath.Base(frame.File), frame.Line, fmt.Sprintf(format, args...))
+	text := fmt.Sprintf("%s:%d FAIL %s%s", filepath.Base(frame.File), frame.Line, c.prefix, fmt.Sprintf(format, args...))
+	if c.color {
+		text = "\033[0;31m" + text + "\033[0m"
+	}
+
+	if !c.fatal {
+		c.failures++
+		c.t.Errorf("%s", text)
+		if c.maxFailures > 0 && c.failures >= c.maxFailures {
+			c.t.Fatalf("too many failures (%d), aborting", c.failures)
+		}
+		return
+	}
+
+	c.t.Fatalf("%s", text)
 }
 
 // equal returns true if the two values can be considered equal
@@ -190,6 +585,30 @@ func compare(a interface{}, b interface{}) int {
 	}
 }
 
+// orderedCompare compares two values that may be numbers or strings, returning 0 if
+// a == b, -1 if b < a, or +1 if b > a. Numbers are compared via compare(); strings
+// are compared lexicographically
+func orderedCompare(a interface{}, b interface{}) int {
+	av, at := getType(a)
+	bv, bt := getType(b)
+
+	if at == stringType || bt == stringType {
+		if at != stringType || bt != stringType {
+			panic(fmt.Sprintf("can't compare %T and %T", a, b))
+		}
+		as := av.(string)
+		bs := bv.(string)
+		if bs < as {
+			return -1
+		} else if bs == as {
+			return 0
+		}
+		return 1
+	}
+
+	return compare(av, bv)
+}
+
 // getType gets the comparison type of the given value and converts it to a base type for easy comparison.
 func getType(v interface{}) (interface{}, comparisonType) {
 	switch v := v.(type) {
@@ -0,0 +1,38 @@
+package testexpect
+
+import "testing"
+
+// fakeT is a minimal TestingT that records whether a failure was reported,
+// for use in this package's own tests
+type fakeT struct {
+	failed bool
+}
+
+func (f *fakeT) Errorf(format string, args ...interface{}) { f.failed = true }
+func (f *fakeT) Fatalf(format string, args ...interface{}) { f.failed = true }
+func (f *fakeT) Helper()                                   {}
+
+func TestBetween(t *testing.T) {
+	cases := []struct {
+		name       string
+		actual     int
+		low, high  int
+		wantFailed bool
+	}{
+		{"in range", 5, 1, 10, false},
+		{"below low", 0, 1, 10, true},
+		{"above high", 11, 1, 10, true},
+		{"equals low", 1, 1, 10, false},
+		{"equals high", 10, 1, 10, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ft := &fakeT{}
+			NewAssertContext(ft).Between("x", tc.actual, tc.low, tc.high)
+			if ft.failed != tc.wantFailed {
+				t.Errorf("Between(%v, %v, %v): failed=%v, want %v", tc.actual, tc.low, tc.high, ft.failed, tc.wantFailed)
+			}
+		})
+	}
+}